@@ -0,0 +1,30 @@
+// Package scheduler exposes the scheduler service over Portainer's HTTP
+// API, for admin operations like hot-reloading its worker pool.
+package scheduler
+
+import (
+	httperror "github.com/portainer/libhttp/error"
+	"github.com/portainer/portainer/api/http/security"
+	"github.com/portainer/portainer/api/scheduler"
+
+	"github.com/gorilla/mux"
+)
+
+// Handler is the HTTP handler used to handle scheduler operations.
+type Handler struct {
+	*mux.Router
+	scheduler *scheduler.Scheduler
+}
+
+// NewHandler creates a handler to manage scheduler operations.
+func NewHandler(bouncer *security.RequestBouncer, schedulerService *scheduler.Scheduler) *Handler {
+	h := &Handler{
+		Router:    mux.NewRouter(),
+		scheduler: schedulerService,
+	}
+
+	h.Handle("/scheduler/reload",
+		bouncer.AdminAccess(httperror.LoggerHandler(h.schedulerReload))).Methods("POST")
+
+	return h
+}
@@ -0,0 +1,84 @@
+package scheduler
+
+import (
+	"errors"
+	"net/http"
+
+	httperror "github.com/portainer/libhttp/error"
+	"github.com/portainer/libhttp/request"
+	"github.com/portainer/libhttp/response"
+	"github.com/portainer/portainer/api/scheduler"
+)
+
+type schedulerReloadPayload struct {
+	MaxConcurrency int `json:"MaxConcurrency"`
+	QueueSize      int `json:"QueueSize"`
+	MinWorkers     int `json:"MinWorkers"`
+	MaxWorkers     int `json:"MaxWorkers"`
+	// DisablePool tears the worker pool down and falls back to unbounded
+	// inline job execution. It must be set explicitly: a payload that
+	// merely omits MaxConcurrency/MaxWorkers is rejected, so a forgotten
+	// field can never disable the pool by accident.
+	DisablePool bool `json:"DisablePool"`
+}
+
+func (payload *schedulerReloadPayload) Validate(r *http.Request) error {
+	if payload.MaxWorkers > 0 && payload.MinWorkers > payload.MaxWorkers {
+		return errors.New("MinWorkers cannot be greater than MaxWorkers")
+	}
+
+	if !payload.DisablePool && payload.MaxConcurrency <= 0 && payload.MaxWorkers <= 0 {
+		return errors.New("MaxConcurrency or MaxWorkers must be set, or DisablePool must be true")
+	}
+
+	return nil
+}
+
+type schedulerReloadResponse struct {
+	Before scheduler.Config `json:"Before"`
+	After  scheduler.Config `json:"After"`
+}
+
+// @id SchedulerReload
+// @summary Hot-reload the scheduler's worker pool configuration
+// @description Reconfigures the scheduler's concurrency settings at
+// runtime, without losing any scheduled job. Set DisablePool to
+// intentionally fall back to unbounded inline execution; otherwise
+// MaxConcurrency or MaxWorkers must be positive.
+// @description **Access policy**: administrator
+// @tags scheduler
+// @security ApiKeyAuth || jwt
+// @accept json
+// @produce json
+// @param body body schedulerReloadPayload true "Scheduler configuration"
+// @success 200 {object} schedulerReloadResponse "Success"
+// @failure 400 "Invalid request"
+// @failure 500 "Server error"
+// @router /scheduler/reload [post]
+func (handler *Handler) schedulerReload(w http.ResponseWriter, r *http.Request) *httperror.HandlerError {
+	var payload schedulerReloadPayload
+	if err := request.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return httperror.BadRequest("Invalid request payload", err)
+	}
+
+	before := handler.scheduler.Config()
+
+	var opts []scheduler.Option
+	if !payload.DisablePool {
+		opts = append(opts, scheduler.WithQueueSize(payload.QueueSize))
+		if payload.MaxWorkers > 0 {
+			opts = append(opts, scheduler.WithDynamicConcurrency(payload.MinWorkers, payload.MaxWorkers))
+		} else {
+			opts = append(opts, scheduler.WithMaxConcurrency(payload.MaxConcurrency))
+		}
+	}
+
+	if err := handler.scheduler.Reconfigure(opts...); err != nil {
+		return httperror.InternalServerError("Unable to reconfigure scheduler", err)
+	}
+
+	return response.JSON(w, schedulerReloadResponse{
+		Before: before,
+		After:  handler.scheduler.Config(),
+	})
+}
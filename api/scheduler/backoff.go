@@ -0,0 +1,55 @@
+package scheduler
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy governs how long the Scheduler waits before retrying a job
+// whose last run returned a transient (non-PermanentError) error. A
+// PermanentError always stops the job immediately, regardless of policy.
+type RetryPolicy struct {
+	// Base is the delay before the first retry.
+	Base time.Duration
+	// Max caps the computed delay, before jitter is added.
+	Max time.Duration
+	// Factor is the multiplier applied to Base for each subsequent
+	// attempt, e.g. 2 to double the delay every time.
+	Factor float64
+	// Jitter adds a random delay in [0, Jitter*delay) on top of the
+	// computed backoff, to avoid retry storms across jobs.
+	Jitter float64
+}
+
+// delay returns the backoff delay for the given attempt (1-indexed).
+func (p *RetryPolicy) delay(attempt int) time.Duration {
+	backoff := float64(p.Base) * math.Pow(p.Factor, float64(attempt-1))
+	if max := float64(p.Max); backoff > max {
+		backoff = max
+	}
+
+	if p.Jitter > 0 {
+		backoff += rand.Float64() * p.Jitter * backoff
+	}
+
+	return time.Duration(backoff)
+}
+
+// StartJobOption configures a job registered via StartJobEvery.
+type StartJobOption func(*jobOptions)
+
+type jobOptions struct {
+	retry    *RetryPolicy
+	overflow OverflowPolicy
+}
+
+// WithBackoff makes a job back off exponentially after a transient error:
+// the next invocation is delayed by min(max, base*factor^attempt), plus
+// uniform jitter in [0, jitter*delay). A successful run resets the
+// attempt counter and returns the job to its configured interval.
+func WithBackoff(base, max time.Duration, factor, jitter float64) StartJobOption {
+	return func(o *jobOptions) {
+		o.retry = &RetryPolicy{Base: base, Max: max, Factor: factor, Jitter: jitter}
+	}
+}
@@ -0,0 +1,69 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_WithBackoff_DelaysAfterTransientError(t *testing.T) {
+	s := NewScheduler(context.Background())
+	defer s.Shutdown()
+
+	var runs atomic.Int64
+	var firstRun, secondRun time.Time
+
+	jobID := s.StartJobEvery(time.Millisecond, func() error {
+		n := runs.Add(1)
+		switch n {
+		case 1:
+			firstRun = time.Now()
+			return errors.New("transient")
+		case 2:
+			secondRun = time.Now()
+		}
+
+		return nil
+	}, WithBackoff(50*time.Millisecond, time.Second, 2, 0))
+
+	assert.Eventually(t, func() bool { return runs.Load() >= 2 }, 2*time.Second, time.Millisecond)
+	s.StopJob(jobID)
+
+	assert.GreaterOrEqual(t, secondRun.Sub(firstRun), 50*time.Millisecond)
+}
+
+func Test_WithBackoff_DelaysAfterTransientError_ThroughPool(t *testing.T) {
+	s := NewSchedulerWithOptions(context.Background(), WithMaxConcurrency(2))
+	defer s.Shutdown()
+
+	var runs atomic.Int64
+	var firstRun, secondRun time.Time
+
+	jobID := s.StartJobEvery(time.Millisecond, func() error {
+		n := runs.Add(1)
+		switch n {
+		case 1:
+			firstRun = time.Now()
+			return errors.New("transient")
+		case 2:
+			secondRun = time.Now()
+		}
+
+		return nil
+	}, WithBackoff(50*time.Millisecond, time.Second, 2, 0))
+
+	assert.Eventually(t, func() bool { return runs.Load() >= 2 }, 2*time.Second, time.Millisecond)
+	s.StopJob(jobID)
+
+	assert.GreaterOrEqual(t, secondRun.Sub(firstRun), 50*time.Millisecond)
+}
+
+func Test_RetryPolicy_Delay_CapsAtMax(t *testing.T) {
+	p := &RetryPolicy{Base: time.Second, Max: 2 * time.Second, Factor: 10}
+
+	assert.Equal(t, 2*time.Second, p.delay(5))
+}
@@ -0,0 +1,181 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronMacros maps the shorthand expressions supported alongside standard
+// 5-field cron syntax to their expanded form.
+var cronMacros = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+}
+
+// cronSchedule is a parsed standard 5-field cron expression:
+// minute hour day-of-month month day-of-week.
+type cronSchedule struct {
+	minutes    fieldSet
+	hours      fieldSet
+	daysOfMon  fieldSet
+	months     fieldSet
+	daysOfWeek fieldSet
+
+	// domRestricted and dowRestricted record whether the day-of-month and
+	// day-of-week fields were anything other than "*". Per standard cron
+	// semantics, when both are restricted a match on either is enough
+	// (OR); otherwise the unrestricted field is ignored and the other
+	// must match on its own (equivalent to AND).
+	domRestricted bool
+	dowRestricted bool
+}
+
+type fieldSet map[int]struct{}
+
+func (f fieldSet) contains(v int) bool {
+	_, ok := f[v]
+	return ok
+}
+
+func parseCron(spec string) (*cronSchedule, error) {
+	if expanded, ok := cronMacros[spec]; ok {
+		spec = expanded
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("scheduler: invalid cron expression %q: expected 5 fields, got %d", spec, len(fields))
+	}
+
+	minutes, err := parseField(fields[0], 0, 59)
+	if err != nil {
+		return nil, fmt.Errorf("scheduler: invalid minute field %q: %w", fields[0], err)
+	}
+
+	hours, err := parseField(fields[1], 0, 23)
+	if err != nil {
+		return nil, fmt.Errorf("scheduler: invalid hour field %q: %w", fields[1], err)
+	}
+
+	daysOfMon, err := parseField(fields[2], 1, 31)
+	if err != nil {
+		return nil, fmt.Errorf("scheduler: invalid day-of-month field %q: %w", fields[2], err)
+	}
+
+	months, err := parseField(fields[3], 1, 12)
+	if err != nil {
+		return nil, fmt.Errorf("scheduler: invalid month field %q: %w", fields[3], err)
+	}
+
+	daysOfWeek, err := parseField(fields[4], 0, 6)
+	if err != nil {
+		return nil, fmt.Errorf("scheduler: invalid day-of-week field %q: %w", fields[4], err)
+	}
+
+	return &cronSchedule{
+		minutes:       minutes,
+		hours:         hours,
+		daysOfMon:     daysOfMon,
+		months:        months,
+		daysOfWeek:    daysOfWeek,
+		domRestricted: fields[2] != "*",
+		dowRestricted: fields[4] != "*",
+	}, nil
+}
+
+// parseField parses a single comma-separated cron field, where each part
+// is "*", a value, a "lo-hi" range, or any of those suffixed with
+// "/step", and returns the set of values in [min, max] it selects.
+func parseField(field string, min, max int) (fieldSet, error) {
+	set := make(fieldSet)
+
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if idx := strings.Index(part, "/"); idx != -1 {
+			var err error
+			rangePart = part[:idx]
+
+			step, err = strconv.Atoi(part[idx+1:])
+			if err != nil || step <= 0 {
+				return nil, fmt.Errorf("invalid step in %q", part)
+			}
+		}
+
+		lo, hi := min, max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+
+			var err error
+			lo, err = strconv.Atoi(bounds[0])
+			if err != nil {
+				return nil, fmt.Errorf("invalid range in %q", part)
+			}
+
+			hi, err = strconv.Atoi(bounds[1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid range in %q", part)
+			}
+		default:
+			v, err := strconv.Atoi(rangePart)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value %q", rangePart)
+			}
+
+			lo, hi = v, v
+		}
+
+		if lo < min || hi > max || lo > hi {
+			return nil, fmt.Errorf("value %q out of range [%d, %d]", part, min, max)
+		}
+
+		for v := lo; v <= hi; v += step {
+			set[v] = struct{}{}
+		}
+	}
+
+	return set, nil
+}
+
+// Next returns the next time after t that matches the schedule, with
+// second and sub-second components truncated to zero. An expression that
+// never matches, such as "0 0 30 2 *", gets the end of the search window
+// back instead.
+func (c *cronSchedule) Next(t time.Time) time.Time {
+	next := t.Truncate(time.Minute).Add(time.Minute)
+
+	// Any expression that can match at all does so within four years,
+	// which covers a Feb 29 day-of-month/month combination on a leap year.
+	limit := next.AddDate(4, 0, 0)
+	for next.Before(limit) {
+		if c.months.contains(int(next.Month())) &&
+			c.dayMatches(next) &&
+			c.hours.contains(next.Hour()) &&
+			c.minutes.contains(next.Minute()) {
+			return next
+		}
+
+		next = next.Add(time.Minute)
+	}
+
+	return limit
+}
+
+// dayMatches reports whether t's day-of-month and day-of-week satisfy the
+// schedule. When both fields are restricted (neither is "*"), standard
+// cron treats them as alternatives: a match on either is enough.
+func (c *cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := c.daysOfMon.contains(t.Day())
+	dowMatch := c.daysOfWeek.contains(int(t.Weekday()))
+
+	if c.domRestricted && c.dowRestricted {
+		return domMatch || dowMatch
+	}
+
+	return domMatch && dowMatch
+}
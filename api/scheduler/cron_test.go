@@ -0,0 +1,61 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseCron_Macros(t *testing.T) {
+	schedule, err := parseCron("@hourly")
+	require.NoError(t, err)
+
+	expanded, err := parseCron("0 * * * *")
+	require.NoError(t, err)
+
+	assert.Equal(t, expanded, schedule)
+}
+
+func Test_ParseCron_InvalidFieldCount(t *testing.T) {
+	_, err := parseCron("* * *")
+	assert.Error(t, err)
+}
+
+func Test_ParseCron_OutOfRange(t *testing.T) {
+	_, err := parseCron("60 * * * *")
+	assert.Error(t, err)
+}
+
+func Test_CronSchedule_Next(t *testing.T) {
+	schedule, err := parseCron("30 4 * * *")
+	require.NoError(t, err)
+
+	from := time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC)
+	next := schedule.Next(from)
+
+	assert.Equal(t, time.Date(2024, time.March, 2, 4, 30, 0, 0, time.UTC), next)
+}
+
+func Test_CronSchedule_Next_SameDayLater(t *testing.T) {
+	schedule, err := parseCron("0 */6 * * *")
+	require.NoError(t, err)
+
+	from := time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC)
+	next := schedule.Next(from)
+
+	assert.Equal(t, time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC), next)
+}
+
+func Test_CronSchedule_Next_DayOfMonthOrDayOfWeek(t *testing.T) {
+	// When both day-of-month and day-of-week are restricted, standard
+	// cron fires on a match of either, not both at once.
+	schedule, err := parseCron("0 0 1 * 1")
+	require.NoError(t, err)
+
+	from := time.Date(2024, time.June, 1, 10, 0, 0, 0, time.UTC)
+	next := schedule.Next(from)
+
+	assert.Equal(t, time.Date(2024, time.June, 3, 0, 0, 0, 0, time.UTC), next)
+}
@@ -0,0 +1,342 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// OverflowPolicy decides what happens to a job's tick when the worker
+// pool's queue is already full.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock waits for room in the queue, applying backpressure to
+	// the dispatcher.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest discards the oldest queued item to make room.
+	OverflowDropOldest
+	// OverflowDropNewest discards the incoming item instead of queueing it.
+	OverflowDropNewest
+	// OverflowCoalesce drops the incoming item if one for the same job is
+	// already queued, collapsing duplicate pending fires into one. Unlike
+	// the other policies it applies whether or not the queue is full; an
+	// item it keeps waits for room like OverflowBlock.
+	OverflowCoalesce
+)
+
+// WithOverflowPolicy sets how a job's tick is handled when the
+// Scheduler's worker pool queue is full. It has no effect unless the
+// Scheduler was created with a worker pool (WithMaxConcurrency or
+// WithDynamicConcurrency); the default policy is OverflowBlock.
+func WithOverflowPolicy(policy OverflowPolicy) StartJobOption {
+	return func(o *jobOptions) {
+		o.overflow = policy
+	}
+}
+
+// workItem is a single job execution enqueued on an executor.
+type workItem struct {
+	id       JobID
+	overflow OverflowPolicy
+	run      func()
+}
+
+// executor runs submitted work items on a bounded pool of goroutines.
+type executor interface {
+	submit(item workItem)
+	// drain tells the pool to stop accepting new submissions and lets its
+	// workers exit once they've worked through anything already queued or
+	// in flight, without waiting for shutdown's full grace period.
+	drain()
+	shutdown()
+}
+
+// queuedPool is the shared enqueue/run bookkeeping behind fixedExecutor
+// and dynamicExecutor: a bounded channel of work items, per-job pending
+// counts for OverflowCoalesce, and execution metrics.
+type queuedPool struct {
+	queue   chan workItem
+	metrics *Metrics
+	closed  atomic.Bool
+
+	mu      sync.Mutex
+	pending map[JobID]int
+}
+
+func newQueuedPool(queueSize int, metrics *Metrics) queuedPool {
+	return queuedPool{
+		queue:   make(chan workItem, queueSize),
+		metrics: metrics,
+		pending: make(map[JobID]int),
+	}
+}
+
+// drain closes the queue so workers exit once they've drained whatever was
+// already queued, instead of waiting indefinitely for ctx cancellation.
+func (p *queuedPool) drain() {
+	p.closed.Store(true)
+	close(p.queue)
+}
+
+func (p *queuedPool) submit(item workItem) {
+	// A drain() racing with an in-flight submit can close the queue out
+	// from under us; treat that as a dropped item rather than a panic.
+	defer func() { recover() }()
+
+	if p.closed.Load() {
+		return
+	}
+
+	p.metrics.JobsEnqueuedTotal.Add(1)
+
+	if item.overflow == OverflowCoalesce {
+		p.mu.Lock()
+		if p.pending[item.id] > 0 {
+			p.mu.Unlock()
+			p.metrics.dropped[OverflowCoalesce].Add(1)
+
+			return
+		}
+		p.pending[item.id]++
+		p.mu.Unlock()
+	}
+
+	switch item.overflow {
+	case OverflowDropNewest:
+		select {
+		case p.queue <- item:
+		default:
+			p.metrics.dropped[OverflowDropNewest].Add(1)
+		}
+	case OverflowDropOldest:
+		for {
+			select {
+			case p.queue <- item:
+				return
+			default:
+			}
+
+			select {
+			case _, ok := <-p.queue:
+				if !ok {
+					return
+				}
+				p.metrics.dropped[OverflowDropOldest].Add(1)
+			default:
+			}
+		}
+	default: // OverflowBlock, OverflowCoalesce
+		p.queue <- item
+	}
+}
+
+func (p *queuedPool) runItem(item workItem) {
+	if item.overflow == OverflowCoalesce {
+		p.mu.Lock()
+		if p.pending[item.id] > 0 {
+			p.pending[item.id]--
+		}
+		p.mu.Unlock()
+	}
+
+	p.metrics.JobsInFlight.Add(1)
+	start := time.Now()
+
+	item.run()
+
+	p.metrics.JobsInFlight.Add(-1)
+	p.metrics.observeDuration(time.Since(start))
+}
+
+// fixedExecutor runs work items on a fixed number of worker goroutines.
+type fixedExecutor struct {
+	queuedPool
+
+	wg sync.WaitGroup
+}
+
+func newFixedExecutor(ctx context.Context, workers, queueSize int, metrics *Metrics) *fixedExecutor {
+	if workers <= 0 {
+		workers = 1
+	}
+	if queueSize <= 0 {
+		queueSize = workers
+	}
+
+	e := &fixedExecutor{queuedPool: newQueuedPool(queueSize, metrics)}
+
+	for i := 0; i < workers; i++ {
+		e.wg.Add(1)
+		go e.worker(ctx)
+	}
+
+	return e
+}
+
+func (e *fixedExecutor) worker(ctx context.Context) {
+	defer e.wg.Done()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case item, ok := <-e.queue:
+			if !ok {
+				return
+			}
+
+			e.runItem(item)
+		}
+	}
+}
+
+func (e *fixedExecutor) shutdown() {
+	e.wg.Wait()
+}
+
+// dynamicExecutor runs work items on a pool that grows and shrinks
+// between min and max workers, based on queue depth sampled over a
+// moving window.
+type dynamicExecutor struct {
+	queuedPool
+
+	ctx      context.Context
+	min, max int
+
+	mu      sync.Mutex
+	stopCh  []chan struct{}
+	workers int
+
+	wg sync.WaitGroup
+}
+
+func newDynamicExecutor(ctx context.Context, minWorkers, maxWorkers, queueSize int, metrics *Metrics) *dynamicExecutor {
+	if minWorkers <= 0 {
+		minWorkers = 1
+	}
+	if maxWorkers < minWorkers {
+		maxWorkers = minWorkers
+	}
+	if queueSize <= 0 {
+		queueSize = maxWorkers
+	}
+
+	e := &dynamicExecutor{
+		queuedPool: newQueuedPool(queueSize, metrics),
+		ctx:        ctx,
+		min:        minWorkers,
+		max:        maxWorkers,
+	}
+
+	for i := 0; i < minWorkers; i++ {
+		e.spawnWorker()
+	}
+
+	e.wg.Add(1)
+	go e.monitor()
+
+	return e
+}
+
+func (e *dynamicExecutor) spawnWorker() {
+	stop := make(chan struct{})
+
+	e.mu.Lock()
+	e.workers++
+	e.stopCh = append(e.stopCh, stop)
+	e.mu.Unlock()
+
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+
+		for {
+			select {
+			case <-e.ctx.Done():
+				return
+			case <-stop:
+				return
+			case item, ok := <-e.queue:
+				if !ok {
+					return
+				}
+
+				e.runItem(item)
+			}
+		}
+	}()
+}
+
+func (e *dynamicExecutor) shrinkByOne() {
+	e.mu.Lock()
+	if len(e.stopCh) <= e.min {
+		e.mu.Unlock()
+
+		return
+	}
+
+	stop := e.stopCh[len(e.stopCh)-1]
+	e.stopCh = e.stopCh[:len(e.stopCh)-1]
+	e.workers--
+	e.mu.Unlock()
+
+	close(stop)
+}
+
+// monitor periodically samples the queue depth and grows the pool when
+// it's been non-empty, or shrinks it when it's been idle, over a short
+// moving window.
+func (e *dynamicExecutor) monitor() {
+	defer e.wg.Done()
+
+	const (
+		tick       = 200 * time.Millisecond
+		windowSize = 5
+	)
+
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	samples := make([]int, 0, windowSize)
+
+	for {
+		select {
+		case <-e.ctx.Done():
+			return
+		case <-ticker.C:
+			// Once drain has closed the queue the workers exit on their
+			// own; stop monitoring so shutdown doesn't wait for ctx.
+			if e.closed.Load() {
+				return
+			}
+
+			samples = append(samples, len(e.queue))
+			if len(samples) > windowSize {
+				samples = samples[len(samples)-windowSize:]
+			}
+
+			sum := 0
+			for _, v := range samples {
+				sum += v
+			}
+			avgDepth := sum / len(samples)
+
+			e.mu.Lock()
+			workers := e.workers
+			e.mu.Unlock()
+
+			switch {
+			case avgDepth > 0 && workers < e.max:
+				e.spawnWorker()
+			case avgDepth == 0 && workers > e.min:
+				e.shrinkByOne()
+			}
+		}
+	}
+}
+
+func (e *dynamicExecutor) shutdown() {
+	e.wg.Wait()
+}
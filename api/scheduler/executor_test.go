@@ -0,0 +1,92 @@
+package scheduler
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FixedExecutor_CapsConcurrency(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	metrics := newMetrics()
+	e := newFixedExecutor(ctx, 2, 10, metrics)
+
+	var inFlight, maxInFlight atomic.Int32
+	release := make(chan struct{})
+
+	for i := 0; i < 5; i++ {
+		e.submit(workItem{id: JobID(i), run: func() {
+			n := inFlight.Add(1)
+			for {
+				cur := maxInFlight.Load()
+				if n <= cur || maxInFlight.CompareAndSwap(cur, n) {
+					break
+				}
+			}
+
+			<-release
+			inFlight.Add(-1)
+		}})
+	}
+
+	assert.Eventually(t, func() bool { return maxInFlight.Load() == 2 }, time.Second, time.Millisecond)
+
+	// Release the workers and cancel before waiting on shutdown: shutdown
+	// blocks until every worker exits, and workers only exit once ctx is
+	// cancelled, so cancelling has to happen first rather than via defer
+	// (defers would unwind in the opposite order and deadlock here).
+	close(release)
+	cancel()
+	e.shutdown()
+}
+
+func Test_QueuedPool_OverflowDropNewest(t *testing.T) {
+	metrics := newMetrics()
+	pool := newQueuedPool(1, metrics)
+
+	pool.queue <- workItem{} // fill the queue
+
+	pool.submit(workItem{overflow: OverflowDropNewest})
+
+	assert.Equal(t, int64(1), metrics.JobsDroppedTotal(OverflowDropNewest))
+}
+
+func Test_QueuedPool_OverflowCoalesce(t *testing.T) {
+	metrics := newMetrics()
+	pool := newQueuedPool(4, metrics)
+
+	pool.submit(workItem{id: 1, overflow: OverflowCoalesce})
+	pool.submit(workItem{id: 1, overflow: OverflowCoalesce})
+
+	assert.Equal(t, 1, len(pool.queue))
+	assert.Equal(t, int64(1), metrics.JobsDroppedTotal(OverflowCoalesce))
+}
+
+func Test_DynamicExecutor_GrowsUnderLoad(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	metrics := newMetrics()
+	e := newDynamicExecutor(ctx, 1, 4, 10, metrics)
+
+	release := make(chan struct{})
+	for i := 0; i < 4; i++ {
+		e.submit(workItem{id: JobID(i), run: func() { <-release }})
+	}
+
+	assert.Eventually(t, func() bool {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+
+		return e.workers > 1
+	}, time.Second, 10*time.Millisecond)
+
+	// See the comment in Test_FixedExecutor_CapsConcurrency: release and
+	// cancel before shutdown, not via defer, or shutdown deadlocks.
+	close(release)
+	cancel()
+	e.shutdown()
+}
@@ -0,0 +1,60 @@
+package scheduler
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Metrics holds the Prometheus-style counters exported by a Scheduler's
+// worker pool, so operators can see starvation under load. It is always
+// present on a Scheduler, but only populated when the Scheduler was
+// created with a worker pool (WithMaxConcurrency or
+// WithDynamicConcurrency); without one, jobs run inline and every
+// counter stays at zero.
+type Metrics struct {
+	// JobsEnqueuedTotal counts every job execution submitted to the
+	// worker pool, regardless of whether it was eventually dropped.
+	JobsEnqueuedTotal atomic.Int64
+	// JobsInFlight is the number of job executions currently running.
+	JobsInFlight atomic.Int64
+
+	dropped [4]atomic.Int64 // indexed by OverflowPolicy
+
+	durMu         sync.Mutex
+	durationSum   time.Duration
+	durationCount int64
+}
+
+func newMetrics() *Metrics {
+	return &Metrics{}
+}
+
+func (m *Metrics) observeDuration(d time.Duration) {
+	m.durMu.Lock()
+	m.durationSum += d
+	m.durationCount++
+	m.durMu.Unlock()
+}
+
+// JobsDroppedTotal returns the number of job executions dropped under
+// policy (jobs_dropped_total{policy}).
+func (m *Metrics) JobsDroppedTotal(policy OverflowPolicy) int64 {
+	return m.dropped[policy].Load()
+}
+
+// JobDurationSecondsSum and JobDurationSecondsCount back a
+// job_duration_seconds summary: divide sum by count for the mean.
+func (m *Metrics) JobDurationSecondsSum() float64 {
+	m.durMu.Lock()
+	defer m.durMu.Unlock()
+
+	return m.durationSum.Seconds()
+}
+
+func (m *Metrics) JobDurationSecondsCount() int64 {
+	m.durMu.Lock()
+	defer m.durMu.Unlock()
+
+	return m.durationCount
+}
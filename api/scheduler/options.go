@@ -0,0 +1,71 @@
+package scheduler
+
+import "time"
+
+// Option configures a Scheduler created with NewSchedulerWithOptions.
+type Option func(*schedulerOptions)
+
+type schedulerOptions struct {
+	maxConcurrency int
+	queueSize      int
+	minWorkers     int
+	maxWorkers     int
+	gracePeriod    time.Duration
+}
+
+// WithMaxConcurrency bounds the number of jobs the Scheduler runs at
+// once to n, using a fixed-size worker pool instead of running jobs
+// inline on the dispatcher goroutine.
+func WithMaxConcurrency(n int) Option {
+	return func(o *schedulerOptions) {
+		o.maxConcurrency = n
+	}
+}
+
+// WithQueueSize bounds the number of pending job executions the
+// Scheduler's worker pool will buffer before applying a job's
+// OverflowPolicy. It has no effect unless WithMaxConcurrency or
+// WithDynamicConcurrency is also used.
+func WithQueueSize(m int) Option {
+	return func(o *schedulerOptions) {
+		o.queueSize = m
+	}
+}
+
+// WithDynamicConcurrency runs jobs on a worker pool that grows and
+// shrinks between min and max workers based on recent queue depth,
+// instead of the fixed size configured by WithMaxConcurrency.
+func WithDynamicConcurrency(min, max int) Option {
+	return func(o *schedulerOptions) {
+		o.minWorkers = min
+		o.maxWorkers = max
+	}
+}
+
+// WithGracePeriod bounds how long Reconfigure waits for the outgoing
+// worker pool to drain its in-flight work before cancelling it outright.
+// It has no effect outside of Reconfigure; the default is 5 seconds.
+func WithGracePeriod(d time.Duration) Option {
+	return func(o *schedulerOptions) {
+		o.gracePeriod = d
+	}
+}
+
+// Config is the externally visible configuration of a Scheduler's worker
+// pool, as set by the Options given to NewSchedulerWithOptions or
+// Reconfigure and reported by Scheduler.Config.
+type Config struct {
+	MaxConcurrency int
+	QueueSize      int
+	MinWorkers     int
+	MaxWorkers     int
+}
+
+func configFromOptions(o schedulerOptions) Config {
+	return Config{
+		MaxConcurrency: o.maxConcurrency,
+		QueueSize:      o.queueSize,
+		MinWorkers:     o.minWorkers,
+		MaxWorkers:     o.maxWorkers,
+	}
+}
@@ -0,0 +1,96 @@
+package scheduler
+
+import (
+	"container/heap"
+	"fmt"
+	"time"
+)
+
+// ResumePolicy decides how a paused job catches up when it is resumed.
+type ResumePolicy int
+
+const (
+	// ResumeWaitForNext keeps the job's next fire time if it is still
+	// ahead; if it passed during the pause, the job skips it and waits for
+	// the trigger's next fire time after now, so it resumes on its normal
+	// schedule without catching up.
+	ResumeWaitForNext ResumePolicy = iota
+	// ResumeFireNow runs the job immediately on resume, to catch up on
+	// the time it spent paused.
+	ResumeFireNow
+)
+
+// PauseJob suspends the fires of the job identified by id. The job stays
+// registered with the scheduler, so ListJobs still reports it and
+// StopJob remains necessary to remove it entirely. It is also pulled out
+// of the fire queue entirely, so a pause spanning many of its ticks never
+// advances (and so never exhausts) a finite trigger's repeat budget;
+// ResumeJob is responsible for putting it back with an up-to-date next
+// fire time.
+func (s *Scheduler) PauseJob(id JobID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[id]
+	if !ok {
+		return fmt.Errorf("scheduler: unknown job %d", id)
+	}
+
+	entry.paused = true
+
+	if entry.index >= 0 {
+		heap.Remove(&s.queue, entry.index)
+	}
+
+	if entry.persisted && s.store != nil {
+		return s.store.UpdateState(int(id), int(JobPaused))
+	}
+
+	return nil
+}
+
+// ResumeJob un-pauses the job identified by id, applying policy to decide
+// whether it catches up immediately or waits for its next natural tick.
+func (s *Scheduler) ResumeJob(id JobID, policy ResumePolicy) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[id]
+	if !ok {
+		return fmt.Errorf("scheduler: unknown job %d", id)
+	}
+
+	entry.paused = false
+
+	switch {
+	case policy == ResumeFireNow:
+		entry.nextFire = time.Now()
+	case entry.index < 0 && !entry.nextFire.After(time.Now()):
+		// The fire time the job had when it was paused went by during the
+		// pause; move on to the trigger's next fire time after now instead
+		// of firing late.
+		next, err := entry.trigger.NextFireTime(time.Now())
+		if err != nil {
+			entry.paused = true
+			return fmt.Errorf("scheduler: resume job %d: %w", id, err)
+		}
+
+		entry.nextFire = next
+	}
+
+	if entry.index < 0 {
+		heap.Push(&s.queue, entry)
+	} else {
+		heap.Fix(&s.queue, entry.index)
+	}
+
+	if entry.persisted && s.store != nil {
+		if err := s.store.UpdateState(int(id), int(JobRunning)); err != nil {
+			return err
+		}
+	}
+
+	s.wakeDispatcher()
+
+	return nil
+}
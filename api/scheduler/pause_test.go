@@ -0,0 +1,88 @@
+package scheduler
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PausedJob_SkipsFires(t *testing.T) {
+	s := NewScheduler(context.Background())
+	defer s.Shutdown()
+
+	var runs atomic.Int64
+	jobID := s.StartJobEvery(jobInterval, func() error {
+		runs.Add(1)
+		return nil
+	})
+
+	err := s.PauseJob(jobID)
+	assert.NoError(t, err)
+
+	<-time.After(3 * jobInterval)
+	assert.Equal(t, int64(0), runs.Load(), "a paused job shouldn't fire")
+
+	statuses := s.ListJobs()
+	assert.Len(t, statuses, 1)
+	assert.Equal(t, JobPaused, statuses[0].State)
+}
+
+func Test_ResumeJob_FireNow(t *testing.T) {
+	s := NewScheduler(context.Background())
+	defer s.Shutdown()
+
+	ch := make(chan struct{})
+	jobID := s.StartJobEvery(time.Hour, func() error {
+		close(ch)
+		return nil
+	})
+
+	assert.NoError(t, s.PauseJob(jobID))
+	assert.NoError(t, s.ResumeJob(jobID, ResumeFireNow))
+
+	select {
+	case <-ch:
+	case <-time.After(3 * jobInterval):
+		t.Fatal("resumed job with ResumeFireNow should have fired immediately")
+	}
+}
+
+func Test_PausedJob_DoesNotConsumeFiniteTriggerBudget(t *testing.T) {
+	s := NewScheduler(context.Background())
+	defer s.Shutdown()
+
+	var runs atomic.Int64
+	trigger := &SimpleTrigger{Interval: 5 * time.Millisecond, RepeatCount: 1}
+
+	jobID, err := s.ScheduleJob(trigger, MisfireFireNow, func() error {
+		runs.Add(1)
+		return nil
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, s.PauseJob(jobID))
+
+	// Several ticks elapse while paused: a trigger with only one repeat
+	// left must not be exhausted by time passing alone.
+	<-time.After(10 * trigger.Interval)
+	assert.Equal(t, int64(0), runs.Load())
+
+	statuses := s.ListJobs()
+	require.Len(t, statuses, 1)
+	assert.Equal(t, JobPaused, statuses[0].State)
+
+	assert.NoError(t, s.ResumeJob(jobID, ResumeFireNow))
+	assert.Eventually(t, func() bool { return runs.Load() >= 1 }, time.Second, time.Millisecond)
+}
+
+func Test_PauseJob_UnknownID(t *testing.T) {
+	s := NewScheduler(context.Background())
+	defer s.Shutdown()
+
+	err := s.PauseJob(JobID(999))
+	assert.Error(t, err)
+}
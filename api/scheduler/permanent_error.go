@@ -0,0 +1,20 @@
+package scheduler
+
+// PermanentError wraps an error to signal that the job that returned it
+// should not be retried or rescheduled again.
+type PermanentError struct {
+	err error
+}
+
+// NewPermanentError wraps err as a PermanentError.
+func NewPermanentError(err error) *PermanentError {
+	return &PermanentError{err: err}
+}
+
+func (e *PermanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *PermanentError) Unwrap() error {
+	return e.err
+}
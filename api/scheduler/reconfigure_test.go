@@ -0,0 +1,31 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Reconfigure_UpdatesConfig(t *testing.T) {
+	s := NewSchedulerWithOptions(context.Background(), WithMaxConcurrency(2))
+	defer s.Shutdown()
+
+	before := s.Config()
+	assert.Equal(t, 2, before.MaxConcurrency)
+
+	err := s.Reconfigure(WithMaxConcurrency(5))
+	assert.NoError(t, err)
+
+	after := s.Config()
+	assert.Equal(t, 5, after.MaxConcurrency)
+}
+
+func Test_Reconfigure_DropsToInlineExecution(t *testing.T) {
+	s := NewSchedulerWithOptions(context.Background(), WithMaxConcurrency(2))
+	defer s.Shutdown()
+
+	err := s.Reconfigure()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, s.Config().MaxConcurrency)
+}
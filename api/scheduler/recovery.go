@@ -0,0 +1,205 @@
+package scheduler
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/portainer/portainer/api/scheduler/store"
+)
+
+// RecoveryPolicy decides what happens, on restart, to a persisted job
+// whose NextFireTime has already passed.
+type RecoveryPolicy int
+
+const (
+	// RecoveryFireNow runs the job immediately to catch up on the fire
+	// that was missed while the process was down.
+	RecoveryFireNow RecoveryPolicy = iota
+	// RecoverySkip drops the missed fire and advances the trigger to
+	// whatever comes after it, without running the job for it.
+	RecoverySkip
+	// RecoveryRescheduleToNext drops every fire missed during downtime
+	// and jumps straight to the trigger's next occurrence after now.
+	RecoveryRescheduleToNext
+)
+
+// NewSchedulerWithStore creates a Scheduler bound to ctx, persists every
+// job scheduled through ScheduleHandler to store, and rehydrates jobs
+// still present in store from a previous run. handlers maps the
+// registered keys ScheduleHandler accepts to the functions they run;
+// since closures can't be serialized, a persisted job whose handler key
+// is no longer registered is skipped.
+func NewSchedulerWithStore(ctx context.Context, jobStore store.JobStore, handlers map[string]func() error, recovery RecoveryPolicy, opts ...Option) (*Scheduler, error) {
+	s := NewSchedulerWithOptions(ctx, opts...)
+	s.store = jobStore
+	s.handlers = handlers
+	s.recovery = recovery
+
+	if err := s.rehydrate(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// ScheduleHandler registers the handler identified by key to run
+// according to trigger, and persists the job so it survives a restart.
+// It returns an error if key was not registered with NewSchedulerWithStore,
+// or if the Scheduler wasn't created with a store at all.
+func (s *Scheduler) ScheduleHandler(key string, trigger Trigger, misfire MisfirePolicy) (JobID, error) {
+	fn, ok := s.handlers[key]
+	if !ok {
+		return 0, fmt.Errorf("scheduler: no handler registered for key %q", key)
+	}
+
+	if s.store == nil {
+		return 0, fmt.Errorf("scheduler: no job store configured")
+	}
+
+	id, err := s.scheduleJob(trigger, misfire, nil, OverflowBlock, fn)
+	if err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	entry := s.entries[id]
+	entry.persisted = true
+	s.mu.Unlock()
+
+	persisted := toPersistedJob(int(id), key, trigger, misfire, entry.nextFire)
+	if err := s.store.SaveJob(persisted); err != nil {
+		s.StopJob(id)
+		return 0, fmt.Errorf("scheduler: persist job: %w", err)
+	}
+
+	return id, nil
+}
+
+// rehydrate loads every job still in s.store and re-registers it,
+// applying s.recovery to any fire that was missed during downtime.
+func (s *Scheduler) rehydrate() error {
+	persisted, err := s.store.LoadAll()
+	if err != nil {
+		return fmt.Errorf("scheduler: load persisted jobs: %w", err)
+	}
+
+	for _, pj := range persisted {
+		fn, ok := s.handlers[pj.HandlerKey]
+		if !ok {
+			continue
+		}
+
+		trigger, err := triggerFromPersisted(pj)
+		if err != nil {
+			continue
+		}
+
+		next := s.recover(trigger, pj.NextFireTime)
+
+		s.mu.Lock()
+		id := JobID(pj.ID)
+		if id >= s.nextID {
+			s.nextID = id + 1
+		}
+
+		entry := &jobEntry{
+			id:        id,
+			trigger:   trigger,
+			fn:        fn,
+			misfire:   MisfirePolicy(pj.Misfire),
+			nextFire:  next,
+			persisted: true,
+			paused:    pj.State == int(JobPaused),
+		}
+		s.entries[id] = entry
+		heap.Push(&s.queue, entry)
+		s.mu.Unlock()
+	}
+
+	s.wakeDispatcher()
+
+	return nil
+}
+
+// recover applies s.recovery to a persisted next fire time that may have
+// already passed while the scheduler was down.
+func (s *Scheduler) recover(trigger Trigger, next time.Time) time.Time {
+	if next.After(time.Now()) {
+		return next
+	}
+
+	switch s.recovery {
+	case RecoveryRescheduleToNext:
+		if n, err := trigger.NextFireTime(time.Now()); err == nil {
+			return n
+		}
+	case RecoverySkip:
+		if n, err := trigger.NextFireTime(next); err == nil {
+			return n
+		}
+	case RecoveryFireNow:
+		fallthrough
+	default:
+		return time.Now()
+	}
+
+	return time.Now()
+}
+
+func toPersistedJob(id int, handlerKey string, trigger Trigger, misfire MisfirePolicy, nextFire time.Time) store.PersistedJob {
+	pj := store.PersistedJob{
+		ID:           id,
+		HandlerKey:   handlerKey,
+		Misfire:      int(misfire),
+		NextFireTime: nextFire,
+		State:        int(JobRunning),
+	}
+
+	switch t := trigger.(type) {
+	case *CronTrigger:
+		pj.TriggerKind = "cron"
+		pj.CronSpec = t.spec
+		pj.Location = t.loc.String()
+	case *SimpleTrigger:
+		pj.TriggerKind = "simple"
+		pj.Interval = t.Interval
+		pj.StartDelay = t.StartDelay
+		pj.RepeatCount = t.RepeatCount
+	case *RunOnceTrigger:
+		pj.TriggerKind = "runonce"
+		pj.RunOnceAt = t.At
+	}
+
+	return pj
+}
+
+func triggerFromPersisted(pj store.PersistedJob) (Trigger, error) {
+	switch pj.TriggerKind {
+	case "cron":
+		loc := time.UTC
+		if pj.Location != "" {
+			if l, err := time.LoadLocation(pj.Location); err == nil {
+				loc = l
+			}
+		}
+
+		return NewCronTrigger(pj.CronSpec, loc)
+	case "simple":
+		return &SimpleTrigger{
+			Interval:    pj.Interval,
+			StartDelay:  pj.StartDelay,
+			RepeatCount: pj.RepeatCount,
+		}, nil
+	case "runonce":
+		// The job's persisted NextFireTime already holds the trigger's only
+		// fire time, so the rebuilt trigger must not hand it out again.
+		t := NewRunOnceTrigger(pj.RunOnceAt)
+		t.fired = true
+
+		return t, nil
+	default:
+		return nil, fmt.Errorf("scheduler: unknown persisted trigger kind %q", pj.TriggerKind)
+	}
+}
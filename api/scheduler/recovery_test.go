@@ -0,0 +1,182 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/portainer/portainer/api/scheduler/store"
+)
+
+// fakeStore is an in-memory store.JobStore used to exercise recovery
+// without depending on a real BoltDB file.
+type fakeStore struct {
+	mu   sync.Mutex
+	jobs map[int]store.PersistedJob
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{jobs: make(map[int]store.PersistedJob)}
+}
+
+func (f *fakeStore) SaveJob(job store.PersistedJob) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.jobs[job.ID] = job
+
+	return nil
+}
+
+func (f *fakeStore) DeleteJob(id int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.jobs, id)
+
+	return nil
+}
+
+func (f *fakeStore) LoadAll() ([]store.PersistedJob, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	jobs := make([]store.PersistedJob, 0, len(f.jobs))
+	for _, job := range f.jobs {
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
+
+func (f *fakeStore) UpdateLastFire(id int, lastFire, nextFire time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	job, ok := f.jobs[id]
+	if !ok {
+		return nil
+	}
+
+	job.LastFireTime = lastFire
+	job.NextFireTime = nextFire
+	f.jobs[id] = job
+
+	return nil
+}
+
+func (f *fakeStore) UpdateState(id int, state int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	job, ok := f.jobs[id]
+	if !ok {
+		return nil
+	}
+
+	job.State = state
+	f.jobs[id] = job
+
+	return nil
+}
+
+func Test_ScheduleHandler_PersistsJob(t *testing.T) {
+	fs := newFakeStore()
+	handlers := map[string]func() error{"noop": func() error { return nil }}
+
+	s, err := NewSchedulerWithStore(context.Background(), fs, handlers, RecoveryFireNow)
+	require.NoError(t, err)
+	defer s.Shutdown()
+
+	_, err = s.ScheduleHandler("noop", NewSimpleTrigger(time.Hour), MisfireFireNow)
+	require.NoError(t, err)
+
+	jobs, err := fs.LoadAll()
+	require.NoError(t, err)
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, "noop", jobs[0].HandlerKey)
+}
+
+func Test_ScheduleHandler_UnregisteredKey(t *testing.T) {
+	fs := newFakeStore()
+	s, err := NewSchedulerWithStore(context.Background(), fs, nil, RecoveryFireNow)
+	require.NoError(t, err)
+	defer s.Shutdown()
+
+	_, err = s.ScheduleHandler("missing", NewSimpleTrigger(time.Hour), MisfireFireNow)
+	assert.Error(t, err)
+}
+
+func Test_ScheduleHandler_PersistsMisfirePolicy(t *testing.T) {
+	fs := newFakeStore()
+	handlers := map[string]func() error{"noop": func() error { return nil }}
+
+	s, err := NewSchedulerWithStore(context.Background(), fs, handlers, RecoveryFireNow)
+	require.NoError(t, err)
+	defer s.Shutdown()
+
+	_, err = s.ScheduleHandler("noop", NewSimpleTrigger(time.Hour), MisfireSkipToNext)
+	require.NoError(t, err)
+
+	jobs, err := fs.LoadAll()
+	require.NoError(t, err)
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, int(MisfireSkipToNext), jobs[0].Misfire)
+}
+
+func Test_NewSchedulerWithStore_RecoversPausedState(t *testing.T) {
+	fs := newFakeStore()
+	handlers := map[string]func() error{"noop": func() error { return nil }}
+
+	require.NoError(t, fs.SaveJob(store.PersistedJob{
+		ID:           1,
+		HandlerKey:   "noop",
+		TriggerKind:  "simple",
+		Interval:     time.Hour,
+		NextFireTime: time.Now().Add(time.Hour),
+		State:        int(JobPaused),
+	}))
+
+	s, err := NewSchedulerWithStore(context.Background(), fs, handlers, RecoveryFireNow)
+	require.NoError(t, err)
+	defer s.Shutdown()
+
+	statuses := s.ListJobs()
+	require.Len(t, statuses, 1)
+	assert.Equal(t, JobPaused, statuses[0].State)
+}
+
+func Test_NewSchedulerWithStore_RecoversMissedFire(t *testing.T) {
+	fs := newFakeStore()
+
+	ran := make(chan struct{})
+	handlers := map[string]func() error{
+		"catch-up": func() error {
+			close(ran)
+			return NewPermanentError(errors.New("done"))
+		},
+	}
+
+	require.NoError(t, fs.SaveJob(store.PersistedJob{
+		ID:           1,
+		HandlerKey:   "catch-up",
+		TriggerKind:  "simple",
+		Interval:     time.Hour,
+		NextFireTime: time.Now().Add(-time.Hour),
+	}))
+
+	s, err := NewSchedulerWithStore(context.Background(), fs, handlers, RecoveryFireNow)
+	require.NoError(t, err)
+	defer s.Shutdown()
+
+	select {
+	case <-ran:
+	case <-time.After(2 * time.Second):
+		t.Fatal("missed fire should have run immediately under RecoveryFireNow")
+	}
+}
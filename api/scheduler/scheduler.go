@@ -0,0 +1,488 @@
+// Package scheduler provides a mechanism to run recurring and one-shot
+// background jobs with cancellation support.
+package scheduler
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/portainer/portainer/api/scheduler/store"
+)
+
+// JobID uniquely identifies a job registered with a Scheduler.
+type JobID int
+
+type jobEntry struct {
+	id        JobID
+	trigger   Trigger
+	fn        func() error
+	misfire   MisfirePolicy
+	nextFire  time.Time
+	lastFire  time.Time
+	lastErr   error
+	retry     *RetryPolicy
+	attempt   int
+	overflow  OverflowPolicy
+	persisted bool
+	paused    bool
+	stopped   bool
+	index     int
+}
+
+// jobQueue is a min-heap of jobEntry ordered by nextFire.
+type jobQueue []*jobEntry
+
+func (q jobQueue) Len() int           { return len(q) }
+func (q jobQueue) Less(i, j int) bool { return q[i].nextFire.Before(q[j].nextFire) }
+func (q jobQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *jobQueue) Push(x any) {
+	e := x.(*jobEntry)
+	e.index = len(*q)
+	*q = append(*q, e)
+}
+
+func (q *jobQueue) Pop() any {
+	old := *q
+	n := len(old)
+	e := old[n-1]
+	old[n-1] = nil
+	e.index = -1
+	*q = old[:n-1]
+
+	return e
+}
+
+// Scheduler runs jobs according to a Trigger, until they are stopped,
+// the Scheduler is shut down, or its parent context is cancelled.
+type Scheduler struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu      sync.Mutex
+	nextID  JobID
+	queue   jobQueue
+	entries map[JobID]*jobEntry
+	wake    chan struct{}
+
+	pool       executor
+	poolCancel context.CancelFunc
+	config     Config
+	metrics    *Metrics
+
+	store    store.JobStore
+	handlers map[string]func() error
+	recovery RecoveryPolicy
+
+	wg sync.WaitGroup
+}
+
+// NewScheduler creates a Scheduler bound to ctx. Cancelling ctx stops
+// every job registered with the Scheduler. Jobs run inline, one at a
+// time, on the Scheduler's own dispatcher goroutine; use
+// NewSchedulerWithOptions to bound concurrency instead.
+func NewScheduler(ctx context.Context) *Scheduler {
+	return NewSchedulerWithOptions(ctx)
+}
+
+// NewSchedulerWithOptions creates a Scheduler bound to ctx, configured by
+// opts. Passing WithMaxConcurrency or WithDynamicConcurrency moves job
+// execution off the dispatcher goroutine and onto a bounded worker pool,
+// so a burst of slow jobs can no longer explode the goroutine count.
+func NewSchedulerWithOptions(ctx context.Context, opts ...Option) *Scheduler {
+	var options schedulerOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	schedCtx, cancel := context.WithCancel(ctx)
+
+	s := &Scheduler{
+		ctx:     schedCtx,
+		cancel:  cancel,
+		entries: make(map[JobID]*jobEntry),
+		wake:    make(chan struct{}, 1),
+		metrics: newMetrics(),
+	}
+
+	poolCtx, poolCancel := context.WithCancel(schedCtx)
+
+	switch {
+	case options.maxWorkers > 0:
+		s.pool = newDynamicExecutor(poolCtx, options.minWorkers, options.maxWorkers, options.queueSize, s.metrics)
+	case options.maxConcurrency > 0:
+		s.pool = newFixedExecutor(poolCtx, options.maxConcurrency, options.queueSize, s.metrics)
+	default:
+		poolCancel()
+	}
+	s.poolCancel = poolCancel
+	s.config = configFromOptions(options)
+
+	s.wg.Add(1)
+	go s.run()
+
+	return s
+}
+
+// Config returns the Scheduler's current worker pool configuration.
+func (s *Scheduler) Config() Config {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.config
+}
+
+// Reconfigure replaces the Scheduler's worker pool with one built from
+// opts, without losing any scheduled job: it spins up a new pool first,
+// switches new work onto it, then tells the old pool to stop accepting
+// work and drain whatever it already had queued or in flight. An idle old
+// pool returns almost immediately; a busy one is only cancelled outright
+// if draining takes longer than WithGracePeriod (5s by default).
+func (s *Scheduler) Reconfigure(opts ...Option) error {
+	var options schedulerOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	gracePeriod := options.gracePeriod
+	if gracePeriod <= 0 {
+		gracePeriod = 5 * time.Second
+	}
+
+	poolCtx, poolCancel := context.WithCancel(s.ctx)
+
+	var newPool executor
+	switch {
+	case options.maxWorkers > 0:
+		newPool = newDynamicExecutor(poolCtx, options.minWorkers, options.maxWorkers, options.queueSize, s.metrics)
+	case options.maxConcurrency > 0:
+		newPool = newFixedExecutor(poolCtx, options.maxConcurrency, options.queueSize, s.metrics)
+	default:
+		poolCancel()
+	}
+
+	s.mu.Lock()
+	oldPool, oldCancel := s.pool, s.poolCancel
+	s.pool, s.poolCancel = newPool, poolCancel
+	s.config = configFromOptions(options)
+	s.mu.Unlock()
+
+	if oldPool == nil {
+		return nil
+	}
+
+	oldPool.drain()
+
+	drained := make(chan struct{})
+	go func() {
+		oldPool.shutdown()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+	case <-time.After(gracePeriod):
+		oldCancel()
+		<-drained
+	}
+
+	return nil
+}
+
+// Metrics returns the Scheduler's Prometheus-style execution counters.
+// They are populated only when the Scheduler was created with a worker
+// pool (WithMaxConcurrency or WithDynamicConcurrency).
+func (s *Scheduler) Metrics() *Metrics {
+	return s.metrics
+}
+
+// ScheduleJob registers fn to run every time trigger fires. misfire
+// decides what happens to a fire that the scheduler notices only after
+// it was already due, e.g. because the process was asleep.
+func (s *Scheduler) ScheduleJob(trigger Trigger, misfire MisfirePolicy, fn func() error) (JobID, error) {
+	return s.scheduleJob(trigger, misfire, nil, OverflowBlock, fn)
+}
+
+func (s *Scheduler) scheduleJob(trigger Trigger, misfire MisfirePolicy, retry *RetryPolicy, overflow OverflowPolicy, fn func() error) (JobID, error) {
+	next, err := trigger.NextFireTime(time.Time{})
+	if err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	id := s.nextID
+	s.nextID++
+
+	entry := &jobEntry{
+		id:       id,
+		trigger:  trigger,
+		fn:       fn,
+		misfire:  misfire,
+		retry:    retry,
+		overflow: overflow,
+		nextFire: next,
+	}
+	s.entries[id] = entry
+	heap.Push(&s.queue, entry)
+	s.mu.Unlock()
+
+	s.wakeDispatcher()
+
+	return id, nil
+}
+
+// StartJobEvery runs fn every interval until it is stopped. It is
+// implemented in terms of a SimpleTrigger for backward compatibility.
+// By default a transient error leaves the job on its regular interval;
+// pass WithBackoff to back off exponentially instead.
+func (s *Scheduler) StartJobEvery(interval time.Duration, fn func() error, opts ...StartJobOption) JobID {
+	var options jobOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	id, _ := s.scheduleJob(NewSimpleTrigger(interval), MisfireFireNow, options.retry, options.overflow, fn)
+
+	return id
+}
+
+// StopJob stops the job identified by id. Stopping an already-stopped or
+// unknown job is a no-op.
+func (s *Scheduler) StopJob(id JobID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if entry, ok := s.entries[id]; ok {
+		entry.stopped = true
+		delete(s.entries, id)
+
+		if entry.persisted && s.store != nil {
+			_ = s.store.DeleteJob(int(id))
+		}
+	}
+}
+
+// Shutdown stops every job registered with the Scheduler and waits for
+// the dispatcher goroutine, and any worker pool, to exit.
+func (s *Scheduler) Shutdown() {
+	s.cancel()
+	s.wg.Wait()
+
+	if s.pool != nil {
+		s.pool.shutdown()
+	}
+}
+
+func (s *Scheduler) wakeDispatcher() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run is the single dispatcher goroutine: it sleeps until the earliest
+// entry in the queue is due, fires it, and reinserts it with its
+// recomputed next fire time.
+func (s *Scheduler) run() {
+	defer s.wg.Done()
+
+	timer := time.NewTimer(time.Hour)
+	if !timer.Stop() {
+		<-timer.C
+	}
+	defer timer.Stop()
+
+	for {
+		s.mu.Lock()
+		hasWork := len(s.queue) > 0
+		var wait time.Duration
+		if hasWork {
+			wait = time.Until(s.queue[0].nextFire)
+		}
+		s.mu.Unlock()
+
+		if hasWork {
+			timer.Reset(wait)
+		}
+
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-s.wake:
+			if hasWork && !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+
+			continue
+		case <-timer.C:
+			s.fireDue()
+		}
+	}
+}
+
+// fireDue pops every entry whose nextFire is due and either runs it
+// inline or hands it to the worker pool. A pool job is re-queued by
+// completeJobRun once its outcome (and any backoff) is known, not here,
+// so a slow dispatcher and a fast worker can't race to apply two
+// different "next fire" times to the same entry. A paused job is popped
+// but never re-queued: ResumeJob puts it back with a freshly computed
+// next fire time, so a pause spanning many ticks never advances (and so
+// never exhausts) a finite trigger's repeat budget.
+func (s *Scheduler) fireDue() {
+	now := time.Now()
+
+	for {
+		s.mu.Lock()
+		if len(s.queue) == 0 || s.queue[0].nextFire.After(now) {
+			s.mu.Unlock()
+			return
+		}
+		entry := heap.Pop(&s.queue).(*jobEntry)
+		s.mu.Unlock()
+
+		if entry.stopped {
+			continue
+		}
+
+		firedAt := entry.nextFire
+
+		s.mu.Lock()
+		paused := entry.paused
+		misfireSkip := !paused && entry.misfire == MisfireSkipToNext && firedAt.Before(now)
+		pool := s.pool
+		s.mu.Unlock()
+
+		if paused {
+			continue
+		}
+
+		if !misfireSkip && pool != nil {
+			fn, id, overflow := entry.fn, entry.id, entry.overflow
+			pool.submit(workItem{
+				id:       id,
+				overflow: overflow,
+				run: func() {
+					err, permanent := runJob(fn)
+					s.completeJobRun(id, firedAt, err, permanent)
+				},
+			})
+
+			continue
+		}
+
+		var backoff time.Duration
+		if !misfireSkip {
+			err, permanent := runJob(entry.fn)
+
+			s.mu.Lock()
+			entry.lastFire = firedAt
+			entry.lastErr = err
+
+			if permanent {
+				s.mu.Unlock()
+				s.StopJob(entry.id)
+				continue
+			}
+
+			if entry.retry != nil {
+				if err == nil {
+					entry.attempt = 0
+				} else {
+					entry.attempt++
+					backoff = entry.retry.delay(entry.attempt)
+				}
+			}
+			s.mu.Unlock()
+		}
+
+		s.requeue(entry, firedAt, backoff)
+	}
+}
+
+// requeue advances entry's trigger past firedAt and reinserts it into the
+// heap with the resulting next fire time, applying backoff instead when
+// it's positive. It stops the job instead if the trigger has no more
+// fire times left.
+func (s *Scheduler) requeue(entry *jobEntry, firedAt time.Time, backoff time.Duration) {
+	next, err := entry.trigger.NextFireTime(firedAt)
+	if err != nil {
+		s.StopJob(entry.id)
+		return
+	}
+
+	if backoff > 0 {
+		next = time.Now().Add(backoff)
+	}
+
+	s.mu.Lock()
+	lastFire := entry.lastFire
+	if _, stillRegistered := s.entries[entry.id]; stillRegistered {
+		entry.nextFire = next
+		heap.Push(&s.queue, entry)
+	}
+	s.mu.Unlock()
+
+	if entry.persisted && s.store != nil {
+		_ = s.store.UpdateLastFire(int(entry.id), lastFire, next)
+	}
+}
+
+// completeJobRun applies the outcome of a job that ran on the worker
+// pool: it records the last fire time/error, stops the job on a
+// PermanentError, and otherwise re-queues it via requeue — applying
+// backoff instead of the trigger's own schedule, for a job configured
+// with WithBackoff, after a transient error.
+func (s *Scheduler) completeJobRun(id JobID, firedAt time.Time, err error, permanent bool) {
+	s.mu.Lock()
+	entry, ok := s.entries[id]
+	if ok {
+		entry.lastFire = firedAt
+		entry.lastErr = err
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		return
+	}
+
+	if permanent {
+		s.StopJob(id)
+		return
+	}
+
+	var backoff time.Duration
+	if entry.retry != nil {
+		s.mu.Lock()
+		if err == nil {
+			entry.attempt = 0
+		} else {
+			entry.attempt++
+			backoff = entry.retry.delay(entry.attempt)
+		}
+		s.mu.Unlock()
+	}
+
+	s.requeue(entry, firedAt, backoff)
+	s.wakeDispatcher()
+}
+
+// runJob invokes fn and reports whether the error it returned was a
+// PermanentError, meaning the job must not run again.
+func runJob(fn func() error) (err error, permanent bool) {
+	err = fn()
+
+	var permErr *PermanentError
+	permanent = errors.As(err, &permErr)
+
+	return err, permanent
+}
@@ -0,0 +1,67 @@
+package scheduler
+
+import "time"
+
+// JobState describes the current lifecycle state of a scheduled job.
+type JobState int
+
+const (
+	// JobRunning means the job fires on its normal schedule.
+	JobRunning JobState = iota
+	// JobPaused means the job's fires are being skipped until it is resumed.
+	JobPaused
+	// JobStopped means the job has been removed from the scheduler.
+	JobStopped
+)
+
+func (s JobState) String() string {
+	switch s {
+	case JobRunning:
+		return "Running"
+	case JobPaused:
+		return "Paused"
+	case JobStopped:
+		return "Stopped"
+	default:
+		return "Unknown"
+	}
+}
+
+// JobStatus is a point-in-time snapshot of a scheduled job, suitable for
+// building an admin view of background work.
+type JobStatus struct {
+	ID           JobID
+	NextFireTime time.Time
+	LastFireTime time.Time
+	LastError    error
+	State        JobState
+	// Attempt is the number of consecutive transient-error retries for
+	// a job configured with WithBackoff; it is always 0 otherwise.
+	Attempt int
+}
+
+// ListJobs returns a snapshot of every job currently registered with the
+// Scheduler, in no particular order.
+func (s *Scheduler) ListJobs() []JobStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	statuses := make([]JobStatus, 0, len(s.entries))
+	for _, entry := range s.entries {
+		state := JobRunning
+		if entry.paused {
+			state = JobPaused
+		}
+
+		statuses = append(statuses, JobStatus{
+			ID:           entry.id,
+			NextFireTime: entry.nextFire,
+			LastFireTime: entry.lastFire,
+			LastError:    entry.lastErr,
+			State:        state,
+			Attempt:      entry.attempt,
+		})
+	}
+
+	return statuses
+}
@@ -0,0 +1,136 @@
+package store
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var jobsBucket = []byte("scheduler_jobs")
+
+// BoltStore is a JobStore backed by a BoltDB database.
+type BoltStore struct {
+	db *bolt.DB
+}
+
+// NewBoltStore opens (creating if necessary) a BoltDB database at path
+// and prepares it to store scheduled jobs.
+func NewBoltStore(path string) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0600, nil)
+	if err != nil {
+		return nil, fmt.Errorf("store: open bolt database: %w", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(jobsBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("store: create bucket: %w", err)
+	}
+
+	return &BoltStore{db: db}, nil
+}
+
+// Close releases the underlying BoltDB database.
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *BoltStore) SaveJob(job PersistedJob) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("store: marshal job %d: %w", job.ID, err)
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(jobsBucket).Put(itob(job.ID), data)
+	})
+}
+
+func (s *BoltStore) DeleteJob(id int) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(jobsBucket).Delete(itob(id))
+	})
+}
+
+func (s *BoltStore) LoadAll() ([]PersistedJob, error) {
+	var jobs []PersistedJob
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(jobsBucket).ForEach(func(_, v []byte) error {
+			var job PersistedJob
+			if err := json.Unmarshal(v, &job); err != nil {
+				return fmt.Errorf("store: unmarshal job: %w", err)
+			}
+
+			jobs = append(jobs, job)
+
+			return nil
+		})
+	})
+
+	return jobs, err
+}
+
+func (s *BoltStore) UpdateLastFire(id int, lastFire, nextFire time.Time) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(jobsBucket)
+
+		data := bucket.Get(itob(id))
+		if data == nil {
+			return fmt.Errorf("store: job %d not found", id)
+		}
+
+		var job PersistedJob
+		if err := json.Unmarshal(data, &job); err != nil {
+			return fmt.Errorf("store: unmarshal job %d: %w", id, err)
+		}
+
+		job.LastFireTime = lastFire
+		job.NextFireTime = nextFire
+
+		updated, err := json.Marshal(job)
+		if err != nil {
+			return fmt.Errorf("store: marshal job %d: %w", id, err)
+		}
+
+		return bucket.Put(itob(id), updated)
+	})
+}
+
+func (s *BoltStore) UpdateState(id int, state int) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(jobsBucket)
+
+		data := bucket.Get(itob(id))
+		if data == nil {
+			return fmt.Errorf("store: job %d not found", id)
+		}
+
+		var job PersistedJob
+		if err := json.Unmarshal(data, &job); err != nil {
+			return fmt.Errorf("store: unmarshal job %d: %w", id, err)
+		}
+
+		job.State = state
+
+		updated, err := json.Marshal(job)
+		if err != nil {
+			return fmt.Errorf("store: marshal job %d: %w", id, err)
+		}
+
+		return bucket.Put(itob(id), updated)
+	})
+}
+
+func itob(id int) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, uint64(id))
+
+	return buf
+}
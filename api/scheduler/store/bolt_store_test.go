@@ -0,0 +1,99 @@
+package store
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_BoltStore_SaveLoadDelete(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scheduler.db")
+
+	s, err := NewBoltStore(path)
+	require.NoError(t, err)
+	defer s.Close()
+
+	job := PersistedJob{
+		ID:           1,
+		HandlerKey:   "prune-images",
+		TriggerKind:  "cron",
+		CronSpec:     "@hourly",
+		NextFireTime: time.Now(),
+	}
+
+	require.NoError(t, s.SaveJob(job))
+
+	jobs, err := s.LoadAll()
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, job.HandlerKey, jobs[0].HandlerKey)
+
+	require.NoError(t, s.DeleteJob(job.ID))
+
+	jobs, err = s.LoadAll()
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+}
+
+func Test_BoltStore_UpdateLastFire(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scheduler.db")
+
+	s, err := NewBoltStore(path)
+	require.NoError(t, err)
+	defer s.Close()
+
+	job := PersistedJob{ID: 1, HandlerKey: "prune-images", TriggerKind: "simple", Interval: time.Minute}
+	require.NoError(t, s.SaveJob(job))
+
+	lastFire := time.Now()
+	nextFire := lastFire.Add(time.Minute)
+	require.NoError(t, s.UpdateLastFire(job.ID, lastFire, nextFire))
+
+	jobs, err := s.LoadAll()
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.WithinDuration(t, nextFire, jobs[0].NextFireTime, time.Millisecond)
+}
+
+func Test_BoltStore_UpdateLastFire_UnknownJob(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scheduler.db")
+
+	s, err := NewBoltStore(path)
+	require.NoError(t, err)
+	defer s.Close()
+
+	err = s.UpdateLastFire(42, time.Now(), time.Now())
+	assert.Error(t, err)
+}
+
+func Test_BoltStore_UpdateState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scheduler.db")
+
+	s, err := NewBoltStore(path)
+	require.NoError(t, err)
+	defer s.Close()
+
+	job := PersistedJob{ID: 1, HandlerKey: "prune-images", TriggerKind: "simple", Interval: time.Minute}
+	require.NoError(t, s.SaveJob(job))
+
+	require.NoError(t, s.UpdateState(job.ID, 1))
+
+	jobs, err := s.LoadAll()
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, 1, jobs[0].State)
+}
+
+func Test_BoltStore_UpdateState_UnknownJob(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scheduler.db")
+
+	s, err := NewBoltStore(path)
+	require.NoError(t, err)
+	defer s.Close()
+
+	err = s.UpdateState(42, 1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,46 @@
+// Package store provides durable storage for scheduler.Scheduler jobs,
+// so they survive a process restart.
+package store
+
+import "time"
+
+// PersistedJob is the durable representation of a scheduled job: enough
+// to rebuild its Trigger and look its handler back up by key after a
+// restart, since closures can't be serialized.
+type PersistedJob struct {
+	ID         int
+	HandlerKey string
+
+	// TriggerKind is one of "cron", "simple", or "runonce"; the fields
+	// below it are only meaningful for the matching kind.
+	TriggerKind string
+	CronSpec    string
+	Location    string
+
+	Interval    time.Duration
+	StartDelay  time.Duration
+	RepeatCount int
+
+	RunOnceAt time.Time
+
+	// Misfire is the int value of the MisfirePolicy the job was
+	// originally scheduled with, restored as-is on rehydration.
+	Misfire int
+
+	LastFireTime time.Time
+	NextFireTime time.Time
+	// State is the int value of a JobState, kept in sync with
+	// Scheduler.PauseJob/ResumeJob so a paused job stays paused across a
+	// restart.
+	State int
+}
+
+// JobStore persists PersistedJob records so a Scheduler can recover its
+// jobs across a restart.
+type JobStore interface {
+	SaveJob(job PersistedJob) error
+	DeleteJob(id int) error
+	LoadAll() ([]PersistedJob, error)
+	UpdateLastFire(id int, lastFire, nextFire time.Time) error
+	UpdateState(id int, state int) error
+}
@@ -0,0 +1,125 @@
+package scheduler
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrTriggerComplete is returned by a Trigger's NextFireTime once the
+// trigger has no more fire times to give.
+var ErrTriggerComplete = errors.New("scheduler: trigger has no more fire times")
+
+// Trigger computes the next time a job should fire, given the time it
+// last fired. prev is the zero time.Time on the first call.
+type Trigger interface {
+	NextFireTime(prev time.Time) (time.Time, error)
+}
+
+// MisfirePolicy decides what happens to a fire time that has already
+// passed by the time the scheduler notices it, e.g. because the process
+// was asleep or the job was paused.
+type MisfirePolicy int
+
+const (
+	// MisfireFireNow runs the job immediately to catch up on the missed
+	// fire, then resumes its normal schedule.
+	MisfireFireNow MisfirePolicy = iota
+	// MisfireSkipToNext drops the missed fire and waits for the next one
+	// computed from the trigger's schedule.
+	MisfireSkipToNext
+)
+
+// CronTrigger fires according to a standard 5-field cron expression
+// (minute hour day-of-month month day-of-week), or one of the
+// @hourly/@daily/@weekly/@monthly macros. Fire times are computed in loc.
+type CronTrigger struct {
+	spec     string
+	schedule *cronSchedule
+	loc      *time.Location
+}
+
+// NewCronTrigger parses spec and returns a CronTrigger that fires in loc.
+// A nil loc defaults to time.Local.
+func NewCronTrigger(spec string, loc *time.Location) (*CronTrigger, error) {
+	schedule, err := parseCron(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	if loc == nil {
+		loc = time.Local
+	}
+
+	return &CronTrigger{spec: spec, schedule: schedule, loc: loc}, nil
+}
+
+func (t *CronTrigger) NextFireTime(prev time.Time) (time.Time, error) {
+	from := prev
+	if from.IsZero() {
+		from = time.Now()
+	}
+
+	return t.schedule.Next(from.In(t.loc)), nil
+}
+
+// SimpleTrigger fires at a fixed interval, optionally delaying its first
+// fire and/or limiting the total number of fires.
+type SimpleTrigger struct {
+	// Interval between fires after the first one.
+	Interval time.Duration
+	// StartDelay is how long to wait, from the moment the trigger is
+	// scheduled, before the first fire.
+	StartDelay time.Duration
+	// RepeatCount caps the number of additional fires after the first
+	// one; -1 repeats forever. NewSimpleTrigger sets -1; the zero value,
+	// 0, fires only once.
+	RepeatCount int
+
+	fireCount int
+}
+
+// NewSimpleTrigger returns a SimpleTrigger that fires every interval,
+// starting after interval has elapsed, forever.
+func NewSimpleTrigger(interval time.Duration) *SimpleTrigger {
+	return &SimpleTrigger{
+		Interval:    interval,
+		StartDelay:  interval,
+		RepeatCount: -1,
+	}
+}
+
+func (t *SimpleTrigger) NextFireTime(prev time.Time) (time.Time, error) {
+	if t.RepeatCount >= 0 && t.fireCount > t.RepeatCount {
+		return time.Time{}, ErrTriggerComplete
+	}
+
+	next := prev.Add(t.Interval)
+	if prev.IsZero() {
+		next = time.Now().Add(t.StartDelay)
+	}
+	t.fireCount++
+
+	return next, nil
+}
+
+// RunOnceTrigger fires a single time, at At.
+type RunOnceTrigger struct {
+	At time.Time
+
+	fired bool
+}
+
+// NewRunOnceTrigger returns a RunOnceTrigger that fires once, at at.
+func NewRunOnceTrigger(at time.Time) *RunOnceTrigger {
+	return &RunOnceTrigger{At: at}
+}
+
+func (t *RunOnceTrigger) NextFireTime(prev time.Time) (time.Time, error) {
+	if t.fired {
+		return time.Time{}, ErrTriggerComplete
+	}
+
+	t.fired = true
+
+	return t.At, nil
+}
@@ -0,0 +1,39 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SimpleTrigger_RepeatCount(t *testing.T) {
+	trigger := NewSimpleTrigger(time.Millisecond)
+	trigger.StartDelay = 0
+	trigger.RepeatCount = 1
+
+	prev := time.Time{}
+
+	next, err := trigger.NextFireTime(prev)
+	assert.NoError(t, err)
+	prev = next
+
+	next, err = trigger.NextFireTime(prev)
+	assert.NoError(t, err)
+	prev = next
+
+	_, err = trigger.NextFireTime(prev)
+	assert.ErrorIs(t, err, ErrTriggerComplete)
+}
+
+func Test_RunOnceTrigger_FiresOnce(t *testing.T) {
+	at := time.Now().Add(time.Minute)
+	trigger := NewRunOnceTrigger(at)
+
+	next, err := trigger.NextFireTime(time.Time{})
+	assert.NoError(t, err)
+	assert.Equal(t, at, next)
+
+	_, err = trigger.NextFireTime(next)
+	assert.ErrorIs(t, err, ErrTriggerComplete)
+}